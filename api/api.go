@@ -0,0 +1,261 @@
+// Package api exposes the JSON CRUD endpoints for todos, mounted at
+// /todo by main. It depends on a service.TodoService rather than a
+// storage.TodoStore directly, sharing validation with package ui.
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/thedevsaddam/renderer"
+
+	"github.com/AdityaMalu/AdityaMalu.github.io/auth"
+	"github.com/AdityaMalu/AdityaMalu.github.io/service"
+	"github.com/AdityaMalu/AdityaMalu.github.io/storage"
+)
+
+const (
+	defaultLimit int64 = 20
+	maxLimit     int64 = 200
+)
+
+// todo is the wire format exchanged with API clients.
+type todo struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Completed bool      `json:"completed"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type handler struct {
+	svc *service.TodoService
+	rnd *renderer.Render
+}
+
+// NewRouter returns the /todo sub-router. Callers are expected to mount it
+// behind auth middleware that populates the request context via
+// auth.UserID.
+func NewRouter(svc *service.TodoService, rnd *renderer.Render) http.Handler {
+	h := &handler{svc: svc, rnd: rnd}
+
+	r := chi.NewRouter()
+	r.Get("/", h.fetchTodos)
+	r.Post("/", h.createTodo)
+	r.Put("/{id}", h.updateTodo)
+	r.Delete("/{id}", h.deleteTodo)
+	r.Post("/import", h.importTodos)
+	r.Get("/export", h.exportTodos)
+	return r
+}
+
+func (h *handler) createTodo(w http.ResponseWriter, r *http.Request) {
+	ownerID, _ := auth.UserID(r.Context())
+
+	var t todo
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		h.rnd.JSON(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	created, err := h.svc.Create(r.Context(), ownerID, t.Title)
+	if err == service.ErrTitleRequired {
+		h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+			"message": "The title field is required",
+		})
+		return
+	}
+	if err != nil {
+		h.rnd.JSON(w, http.StatusInternalServerError, renderer.M{
+			"message": "Failed to save todo",
+			"error":   err,
+		})
+		return
+	}
+
+	h.rnd.JSON(w, http.StatusCreated, renderer.M{
+		"message": "Todo created successfully",
+		"todo_id": created.ID,
+	})
+}
+
+func (h *handler) updateTodo(w http.ResponseWriter, r *http.Request) {
+	ownerID, _ := auth.UserID(r.Context())
+	id := strings.TrimSpace(chi.URLParam(r, "id"))
+
+	var t todo
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		h.rnd.JSON(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err := h.svc.Update(r.Context(), ownerID, id, t.Title, t.Completed)
+	switch err {
+	case nil:
+		h.rnd.JSON(w, http.StatusOK, renderer.M{
+			"message": "Todo updated successfully",
+		})
+	case service.ErrTitleRequired:
+		h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+			"message": "The title field is required",
+		})
+	case storage.ErrInvalidID:
+		h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+			"message": "Invalid ID format",
+		})
+	case storage.ErrNotFound:
+		h.rnd.JSON(w, http.StatusNotFound, renderer.M{
+			"message": "Todo not found",
+		})
+	case storage.ErrForbidden:
+		h.rnd.JSON(w, http.StatusForbidden, renderer.M{
+			"message": "You don't have access to this todo",
+		})
+	default:
+		h.rnd.JSON(w, http.StatusInternalServerError, renderer.M{
+			"message": "Failed to update todo",
+			"error":   err,
+		})
+	}
+}
+
+// sortableColumns is the set of query-string `sort_column` values accepted
+// and passed through as storage.ListParams.SortBy, so callers can't sort
+// on arbitrary fields.
+var sortableColumns = map[string]bool{
+	"title":      true,
+	"completed":  true,
+	"created_at": true,
+}
+
+func parseTodoListParams(r *http.Request) (storage.ListParams, string) {
+	q := r.URL.Query()
+
+	limit := defaultLimit
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			return storage.ListParams{}, "limit must be a positive integer"
+		}
+		if n > maxLimit {
+			return storage.ListParams{}, "limit must not exceed " + strconv.FormatInt(maxLimit, 10)
+		}
+		limit = n
+	}
+
+	var offset int64
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			return storage.ListParams{}, "offset must be a non-negative integer"
+		}
+		offset = n
+	} else if v := q.Get("page"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			return storage.ListParams{}, "page must be a positive integer"
+		}
+		offset = (n - 1) * limit
+	}
+
+	params := storage.ListParams{Limit: limit, Offset: offset, SortBy: "created_at"}
+
+	if v := q.Get("completed"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return storage.ListParams{}, "completed must be a boolean"
+		}
+		params.Completed = &b
+	}
+
+	if v := q.Get("sort_column"); v != "" {
+		if !sortableColumns[v] {
+			return storage.ListParams{}, "sort_column must be one of title, completed, created_at"
+		}
+		params.SortBy = v
+	}
+
+	if v := q.Get("sort_order"); v != "" {
+		switch strings.ToLower(v) {
+		case "asc":
+			params.SortDesc = false
+		case "desc":
+			params.SortDesc = true
+		default:
+			return storage.ListParams{}, "sort_order must be asc or desc"
+		}
+	}
+
+	return params, ""
+}
+
+func (h *handler) fetchTodos(w http.ResponseWriter, r *http.Request) {
+	ownerID, _ := auth.UserID(r.Context())
+
+	params, errMsg := parseTodoListParams(r)
+	if errMsg != "" {
+		h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+			"message": errMsg,
+		})
+		return
+	}
+
+	items, total, err := h.svc.List(r.Context(), ownerID, params)
+	if err != nil {
+		h.rnd.JSON(w, http.StatusInternalServerError, renderer.M{
+			"message": "Failed to fetch todos",
+			"error":   err,
+		})
+		return
+	}
+
+	todoList := make([]todo, 0, len(items))
+	for _, t := range items {
+		todoList = append(todoList, todo{
+			ID:        t.ID,
+			Title:     t.Title,
+			Completed: t.Completed,
+			CreatedAt: t.CreatedAt,
+		})
+	}
+
+	h.rnd.JSON(w, http.StatusOK, renderer.M{
+		"data":   todoList,
+		"total":  total,
+		"limit":  params.Limit,
+		"offset": params.Offset,
+	})
+}
+
+func (h *handler) deleteTodo(w http.ResponseWriter, r *http.Request) {
+	ownerID, _ := auth.UserID(r.Context())
+	id := strings.TrimSpace(chi.URLParam(r, "id"))
+
+	err := h.svc.Delete(r.Context(), ownerID, id)
+	switch err {
+	case nil:
+		h.rnd.JSON(w, http.StatusOK, renderer.M{
+			"message": "Todo deleted successfully",
+		})
+	case storage.ErrInvalidID:
+		h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+			"message": "Invalid ID format",
+		})
+	case storage.ErrNotFound:
+		h.rnd.JSON(w, http.StatusNotFound, renderer.M{
+			"message": "Todo not found",
+		})
+	case storage.ErrForbidden:
+		h.rnd.JSON(w, http.StatusForbidden, renderer.M{
+			"message": "You don't have access to this todo",
+		})
+	default:
+		h.rnd.JSON(w, http.StatusInternalServerError, renderer.M{
+			"message": "Failed to delete todo",
+			"error":   err,
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/thedevsaddam/renderer"
+
+	"github.com/AdityaMalu/AdityaMalu.github.io/auth"
+	"github.com/AdityaMalu/AdityaMalu.github.io/storage"
+)
+
+// importBatchSize caps how many decoded records are buffered before being
+// handed to the store, so a large upload is streamed rather than loaded
+// into memory all at once.
+const importBatchSize = 500
+
+// importDecoder yields one storage.ImportRecord at a time from the
+// request body, regardless of whether it's a JSON array or NDJSON.
+type importDecoder interface {
+	next() (storage.ImportRecord, bool, error)
+}
+
+func newImportDecoder(r *http.Request) (importDecoder, error) {
+	if strings.Contains(r.Header.Get("Content-Type"), "ndjson") {
+		return &ndjsonDecoder{scanner: bufio.NewScanner(r.Body)}, nil
+	}
+
+	dec := json.NewDecoder(r.Body)
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return nil, errors.New("expected a JSON array")
+	}
+	return &jsonArrayDecoder{dec: dec}, nil
+}
+
+// ndjsonDecoder reads one JSON object per line.
+type ndjsonDecoder struct {
+	scanner *bufio.Scanner
+}
+
+func (d *ndjsonDecoder) next() (storage.ImportRecord, bool, error) {
+	for d.scanner.Scan() {
+		line := bytes.TrimSpace(d.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var rec storage.ImportRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return storage.ImportRecord{}, false, err
+		}
+		return rec, true, nil
+	}
+	return storage.ImportRecord{}, false, d.scanner.Err()
+}
+
+// jsonArrayDecoder reads elements out of a top-level JSON array using
+// Token/Decode so the whole array never has to be buffered at once.
+type jsonArrayDecoder struct {
+	dec *json.Decoder
+}
+
+func (d *jsonArrayDecoder) next() (storage.ImportRecord, bool, error) {
+	if !d.dec.More() {
+		return storage.ImportRecord{}, false, nil
+	}
+	var rec storage.ImportRecord
+	if err := d.dec.Decode(&rec); err != nil {
+		return storage.ImportRecord{}, false, err
+	}
+	return rec, true, nil
+}
+
+func (h *handler) importTodos(w http.ResponseWriter, r *http.Request) {
+	ownerID, _ := auth.UserID(r.Context())
+
+	dec, err := newImportDecoder(r)
+	if err != nil {
+		h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+			"message": "Invalid import payload",
+			"error":   err,
+		})
+		return
+	}
+
+	var result storage.ImportResult
+	batch := make([]storage.ImportRecord, 0, importBatchSize)
+	offset := 0
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		res, err := h.svc.Import(r.Context(), ownerID, batch, offset)
+		result.Inserted += res.Inserted
+		result.Failed += res.Failed
+		result.Errors = append(result.Errors, res.Errors...)
+		offset += len(batch)
+		batch = batch[:0]
+		return err
+	}
+
+	for {
+		rec, ok, err := dec.next()
+		if err != nil {
+			h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+				"message": "Invalid import payload",
+				"error":   err,
+			})
+			return
+		}
+		if !ok {
+			break
+		}
+
+		batch = append(batch, rec)
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				h.rnd.JSON(w, http.StatusInternalServerError, renderer.M{
+					"message": "Failed to import todos",
+					"error":   err,
+				})
+				return
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		h.rnd.JSON(w, http.StatusInternalServerError, renderer.M{
+			"message": "Failed to import todos",
+			"error":   err,
+		})
+		return
+	}
+
+	h.rnd.JSON(w, http.StatusOK, renderer.M{
+		"inserted": result.Inserted,
+		"failed":   result.Failed,
+		"errors":   result.Errors,
+	})
+}
+
+func (h *handler) exportTodos(w http.ResponseWriter, r *http.Request) {
+	ownerID, _ := auth.UserID(r.Context())
+
+	ndjson := strings.Contains(r.Header.Get("Accept"), "application/x-ndjson")
+	if ndjson {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+	}
+
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+
+	first := true
+	if !ndjson {
+		w.Write([]byte("["))
+	}
+
+	err := h.svc.Export(r.Context(), ownerID, func(t storage.Todo) error {
+		rec := todo{ID: t.ID, Title: t.Title, Completed: t.Completed, CreatedAt: t.CreatedAt}
+		if !ndjson && !first {
+			if _, err := w.Write([]byte(",")); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		if err := enc.Encode(rec); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+
+	if !ndjson {
+		w.Write([]byte("]"))
+	}
+	if err != nil {
+		log.Printf("export stream error: %v", err)
+	}
+}
@@ -0,0 +1,339 @@
+// Package auth provides JWT-based registration, login and request
+// authentication for the todo API, backed by a storage.UserStore. The same
+// token is accepted two ways: as an `Authorization: Bearer` header for API
+// clients (Middleware), or as an http-only cookie for the browser-facing
+// HTML UI (CookieMiddleware), since a browser navigating via links and form
+// submissions has no way to attach a custom header.
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/thedevsaddam/renderer"
+
+	"github.com/AdityaMalu/AdityaMalu.github.io/storage"
+)
+
+// contextKey is an unexported type for context keys defined in this
+// package, to avoid collisions with keys from other packages.
+type contextKey int
+
+const (
+	userIDKey contextKey = iota
+	userIDRecorderKey
+)
+
+// UserIDRecorder lets middleware mounted outside the authenticated group
+// (e.g. an access logger) observe the user ID Middleware authenticates,
+// even though it only learns it partway through the handler chain. Since
+// r.WithContext returns a new *http.Request, a value Middleware attaches to
+// its own copy is invisible to an outer closure holding the original one;
+// threading a pointer through the context instead lets Middleware fill it
+// in and the outer closure read it back once ServeHTTP returns.
+type UserIDRecorder struct {
+	id string
+}
+
+// UserID returns the user ID recorded during the request, or "" if
+// Middleware never ran (e.g. the route isn't in an authenticated group) or
+// hasn't reached the recorder's position in the chain yet.
+func (rec *UserIDRecorder) UserID() string {
+	if rec == nil {
+		return ""
+	}
+	return rec.id
+}
+
+// WithUserIDRecorder returns a context carrying a new UserIDRecorder that
+// Middleware will fill in if it runs later in the chain, along with the
+// recorder itself so the caller can read it back after the chain returns.
+func WithUserIDRecorder(ctx context.Context) (context.Context, *UserIDRecorder) {
+	rec := &UserIDRecorder{}
+	return context.WithValue(ctx, userIDRecorderKey, rec), rec
+}
+
+// Service issues and validates JWTs and registers/authenticates users
+// against a storage.UserStore.
+type Service struct {
+	users  storage.UserStore
+	secret []byte
+	ttl    time.Duration
+	rnd    *renderer.Render
+}
+
+// NewService returns a Service backed by users, signing tokens with secret
+// and setting them to expire after ttl.
+func NewService(users storage.UserStore, secret []byte, ttl time.Duration, rnd *renderer.Render) *Service {
+	return &Service{users: users, secret: secret, ttl: ttl, rnd: rnd}
+}
+
+type claims struct {
+	jwt.RegisteredClaims
+}
+
+type credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Register handles POST /auth/register: it creates the account and
+// returns a token for it, same as Login would.
+func (s *Service) Register(w http.ResponseWriter, r *http.Request) {
+	var creds credentials
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+		s.rnd.JSON(w, http.StatusBadRequest, renderer.M{"message": "Invalid request body"})
+		return
+	}
+	if creds.Username == "" || creds.Password == "" {
+		s.rnd.JSON(w, http.StatusBadRequest, renderer.M{"message": "username and password are required"})
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(creds.Password), bcrypt.DefaultCost)
+	if err != nil {
+		s.rnd.JSON(w, http.StatusInternalServerError, renderer.M{"message": "Failed to hash password", "error": err})
+		return
+	}
+
+	user, err := s.users.CreateUser(r.Context(), creds.Username, string(hash))
+	if err == storage.ErrDuplicateUser {
+		s.rnd.JSON(w, http.StatusBadRequest, renderer.M{"message": "username already exists"})
+		return
+	}
+	if err != nil {
+		s.rnd.JSON(w, http.StatusInternalServerError, renderer.M{"message": "Failed to create user", "error": err})
+		return
+	}
+
+	token, err := s.issueToken(user.ID)
+	if err != nil {
+		s.rnd.JSON(w, http.StatusInternalServerError, renderer.M{"message": "Failed to issue token", "error": err})
+		return
+	}
+
+	s.rnd.JSON(w, http.StatusCreated, renderer.M{"token": token})
+}
+
+// Login handles POST /auth/login.
+func (s *Service) Login(w http.ResponseWriter, r *http.Request) {
+	var creds credentials
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+		s.rnd.JSON(w, http.StatusBadRequest, renderer.M{"message": "Invalid request body"})
+		return
+	}
+
+	user, err := s.users.GetUserByUsername(r.Context(), creds.Username)
+	if err != nil || bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(creds.Password)) != nil {
+		s.rnd.JSON(w, http.StatusUnauthorized, renderer.M{"message": "invalid username or password"})
+		return
+	}
+
+	token, err := s.issueToken(user.ID)
+	if err != nil {
+		s.rnd.JSON(w, http.StatusInternalServerError, renderer.M{"message": "Failed to issue token", "error": err})
+		return
+	}
+
+	s.rnd.JSON(w, http.StatusOK, renderer.M{"token": token})
+}
+
+func (s *Service) issueToken(userID string) (string, error) {
+	now := time.Now()
+	c := claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.ttl)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString(s.secret)
+}
+
+// parseToken validates tokenStr's signature and expiry and returns the
+// claims it carries. It's shared by Middleware and CookieMiddleware, which
+// only differ in where they find the token.
+func (s *Service) parseToken(tokenStr string) (*claims, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &claims{}, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return s.secret, nil
+	})
+	if err != nil || !token.Valid {
+		if err == nil {
+			err = errors.New("invalid token")
+		}
+		return nil, err
+	}
+	return token.Claims.(*claims), nil
+}
+
+// authenticate stores userID in ctx for UserID to read, and in rec (if the
+// context carries a UserIDRecorder) so middleware mounted outside the
+// authenticated group can observe it too.
+func authenticate(ctx context.Context, userID string) context.Context {
+	ctx = context.WithValue(ctx, userIDKey, userID)
+	if rec, ok := ctx.Value(userIDRecorderKey).(*UserIDRecorder); ok {
+		rec.id = userID
+	}
+	return ctx
+}
+
+// Middleware validates the `Authorization: Bearer <token>` header on
+// incoming requests, rejecting with 401 when it's missing or invalid, and
+// injects the authenticated user's ID into the request context for
+// handlers to read with UserID.
+func (s *Service) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			s.rnd.JSON(w, http.StatusUnauthorized, renderer.M{"message": "missing or invalid Authorization header"})
+			return
+		}
+
+		c, err := s.parseToken(parts[1])
+		if err != nil {
+			s.rnd.JSON(w, http.StatusUnauthorized, renderer.M{"message": "invalid or expired token"})
+			return
+		}
+
+		ctx := authenticate(r.Context(), c.Subject)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// cookieName is the http-only cookie CookieMiddleware reads the token from
+// and the web login/logout handlers set/clear it on.
+const cookieName = "auth_token"
+
+// CookieMiddleware is Middleware's cookie-based counterpart for the
+// browser-facing HTML UI: it reads the token from the cookieName cookie
+// instead of an Authorization header, and redirects to /ui/login instead of
+// returning a JSON 401, since a browser following a link can't do anything
+// useful with one.
+func (s *Service) CookieMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(cookieName)
+		if err != nil {
+			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+			return
+		}
+
+		c, err := s.parseToken(cookie.Value)
+		if err != nil {
+			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+			return
+		}
+
+		ctx := authenticate(r.Context(), c.Subject)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// setAuthCookie sets token as an http-only session cookie, valid for the
+// same period as the token itself.
+func (s *Service) setAuthCookie(w http.ResponseWriter, token string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int(s.ttl.Seconds()),
+	})
+}
+
+// WebLogin handles POST /ui/login: it authenticates the same way Login
+// does, but reads form fields instead of a JSON body and, on success, sets
+// a session cookie and redirects to /ui/todos instead of returning a token.
+func (s *Service) WebLogin(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form submission", http.StatusBadRequest)
+		return
+	}
+
+	user, err := s.users.GetUserByUsername(r.Context(), r.FormValue("username"))
+	if err != nil || bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(r.FormValue("password"))) != nil {
+		http.Redirect(w, r, "/ui/login?error=1", http.StatusSeeOther)
+		return
+	}
+
+	token, err := s.issueToken(user.ID)
+	if err != nil {
+		http.Error(w, "failed to issue token", http.StatusInternalServerError)
+		return
+	}
+
+	s.setAuthCookie(w, token)
+	http.Redirect(w, r, "/ui/todos", http.StatusSeeOther)
+}
+
+// WebRegister handles POST /ui/register: the form-based, cookie-issuing
+// counterpart to Register.
+func (s *Service) WebRegister(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form submission", http.StatusBadRequest)
+		return
+	}
+
+	username, password := r.FormValue("username"), r.FormValue("password")
+	if username == "" || password == "" {
+		http.Redirect(w, r, "/ui/register?error=1", http.StatusSeeOther)
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		http.Error(w, "failed to hash password", http.StatusInternalServerError)
+		return
+	}
+
+	user, err := s.users.CreateUser(r.Context(), username, string(hash))
+	if err == storage.ErrDuplicateUser {
+		http.Redirect(w, r, "/ui/register?error=1", http.StatusSeeOther)
+		return
+	}
+	if err != nil {
+		http.Error(w, "failed to create user", http.StatusInternalServerError)
+		return
+	}
+
+	token, err := s.issueToken(user.ID)
+	if err != nil {
+		http.Error(w, "failed to issue token", http.StatusInternalServerError)
+		return
+	}
+
+	s.setAuthCookie(w, token)
+	http.Redirect(w, r, "/ui/todos", http.StatusSeeOther)
+}
+
+// WebLogout handles POST /ui/logout: it clears the session cookie set by
+// WebLogin/WebRegister.
+func (s *Service) WebLogout(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookieName,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
+	})
+	http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+}
+
+// UserID returns the authenticated user's ID stored in ctx by
+// Service.Middleware or Service.CookieMiddleware, and whether one was
+// present.
+func UserID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(userIDKey).(string)
+	return id, ok
+}
@@ -0,0 +1,46 @@
+// Package logging provides a chi middleware that emits one structured
+// JSON access log line per request via zerolog.
+package logging
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/rs/zerolog"
+
+	"github.com/AdityaMalu/AdityaMalu.github.io/auth"
+)
+
+// Middleware logs method, path, status and duration for every request,
+// plus request_id (set by chi's middleware.RequestID, which must run
+// first) and user_id (set once auth middleware has run, blank otherwise).
+//
+// Middleware is typically mounted ahead of auth.Service.Middleware in the
+// chain (so unauthenticated routes are logged too), which means it can't
+// simply read the user ID back off its own *http.Request after calling
+// next.ServeHTTP: auth.Service.Middleware forwards the authenticated
+// context via r.WithContext, which produces a new *http.Request that's
+// invisible to this closure's r. Instead it hands next an
+// auth.UserIDRecorder through the context, which auth.Service.Middleware
+// fills in by pointer if it runs later in the chain.
+func Middleware(logger zerolog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			ctx, rec := auth.WithUserIDRecorder(r.Context())
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			logger.Info().
+				Str("request_id", middleware.GetReqID(r.Context())).
+				Str("method", r.Method).
+				Str("path", r.URL.Path).
+				Int("status", ww.Status()).
+				Int64("duration_ms", time.Since(start).Milliseconds()).
+				Str("user_id", rec.UserID()).
+				Msg("request")
+		})
+	}
+}
@@ -1,270 +1,241 @@
-package main
-
-import (
-    "context"
-    "encoding/json"
-    "log"
-    "net/http"
-    "os"
-    "os/signal"
-    "strings"
-    "time"
-
-    "github.com/go-chi/chi"
-    "github.com/go-chi/chi/middleware"
-    "github.com/thedevsaddam/renderer"
-    "go.mongodb.org/mongo-driver/bson"
-    "go.mongodb.org/mongo-driver/mongo"
-    "go.mongodb.org/mongo-driver/mongo/options"
-    "go.mongodb.org/mongo-driver/mongo/readpref"
-    "go.mongodb.org/mongo-driver/bson/primitive"
-)
-
-var rnd *renderer.Render
-var db *mongo.Database
-var client *mongo.Client
-
-const (
-    hostName       string = "mongodb://localhost:27017"
-    dbName         string = "demo_todo"
-    collectionName string = "todo"
-    port           string = ":9000"
-)
-
-type (
-    todoModel struct {
-        ID        primitive.ObjectID `bson:"_id,omitempty"`
-        Title     string             `bson:"title"`
-        Completed bool               `bson:"completed"`
-        CreatedAt time.Time          `bson:"createdAt"`
-    }
-
-    todo struct {
-        ID        string    `json:"id"`
-        Title     string    `json:"title"`
-        Completed bool      `json:"completed"`
-        CreatedAt time.Time `json:"created_at"`
-    }
-)
-
-func init() {
-    rnd = renderer.New()
-    clientOptions := options.Client().ApplyURI(hostName)
-    var err error
-    client, err = mongo.Connect(context.TODO(), clientOptions)
-    checkErr(err)
-
-    err = client.Ping(context.TODO(), readpref.Primary())
-    checkErr(err)
-
-    db = client.Database(dbName)
-}
-
-func homeHandler(w http.ResponseWriter, r *http.Request) {
-    err := rnd.Template(w, http.StatusOK, []string{"static/home.tpl"}, nil)
-    checkErr(err)
-}
-
-func createTodo(w http.ResponseWriter, r *http.Request) {
-    var t todo
-
-    if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
-        rnd.JSON(w, http.StatusInternalServerError, err)
-        return
-    }
-
-    if t.Title == "" {
-        rnd.JSON(w, http.StatusBadRequest, renderer.M{
-            "message": "The title field is required",
-        })
-        return
-    }
-
-    tm := todoModel{
-        ID:        primitive.NewObjectID(),
-        Title:     t.Title,
-        Completed: false,
-        CreatedAt: time.Now(),
-    }
-    collection := db.Collection(collectionName)
-    _, err := collection.InsertOne(context.TODO(), tm)
-    if err != nil {
-        rnd.JSON(w, http.StatusInternalServerError, renderer.M{
-            "message": "Failed to save todo",
-            "error":   err,
-        })
-        return
-    }
-
-    rnd.JSON(w, http.StatusCreated, renderer.M{
-        "message": "Todo created successfully",
-        "todo_id": tm.ID.Hex(),
-    })
-}
-
-func updateTodo(w http.ResponseWriter, r *http.Request) {
-    id := strings.TrimSpace(chi.URLParam(r, "id"))
-    objID, err := primitive.ObjectIDFromHex(id)
-    if err != nil {
-        rnd.JSON(w, http.StatusBadRequest, renderer.M{
-            "message": "Invalid ID format",
-        })
-        return
-    }
-
-    var t todo
-
-    if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
-        rnd.JSON(w, http.StatusInternalServerError, err)
-        return
-    }
-
-    if t.Title == "" {
-        rnd.JSON(w, http.StatusBadRequest, renderer.M{
-            "message": "The title field is required",
-        })
-        return
-    }
-
-    collection := db.Collection(collectionName)
-    filter := bson.M{"_id": objID}
-    update := bson.M{"$set": bson.M{"title": t.Title, "completed": t.Completed}}
-    _, err = collection.UpdateOne(context.TODO(), filter, update)
-    if err != nil {
-        rnd.JSON(w, http.StatusInternalServerError, renderer.M{
-            "message": "Failed to update todo",
-            "error":   err,
-        })
-        return
-    }
-
-    rnd.JSON(w, http.StatusOK, renderer.M{
-        "message": "Todo updated successfully",
-    })
-}
-
-func fetchTodos(w http.ResponseWriter, r *http.Request) {
-    collection := db.Collection(collectionName)
-    cursor, err := collection.Find(context.TODO(), bson.M{})
-    if err != nil {
-        rnd.JSON(w, http.StatusInternalServerError, renderer.M{
-            "message": "Failed to fetch todos",
-            "error":   err,
-        })
-        return
-    }
-    defer cursor.Close(context.TODO())
-
-    todos := []todoModel{}
-    for cursor.Next(context.TODO()) {
-        var t todoModel
-        err := cursor.Decode(&t)
-        if err != nil {
-            rnd.JSON(w, http.StatusInternalServerError, renderer.M{
-                "message": "Failed to decode todo",
-                "error":   err,
-            })
-            return
-        }
-        todos = append(todos, t)
-    }
-
-    todoList := []todo{}
-    for _, t := range todos {
-        todoList = append(todoList, todo{
-            ID:        t.ID.Hex(),
-            Title:     t.Title,
-            Completed: t.Completed,
-            CreatedAt: t.CreatedAt,
-        })
-    }
-
-    rnd.JSON(w, http.StatusOK, renderer.M{
-        "data": todoList,
-    })
-}
-
-func deleteTodo(w http.ResponseWriter, r *http.Request) {
-    id := strings.TrimSpace(chi.URLParam(r, "id"))
-    objID, err := primitive.ObjectIDFromHex(id)
-    if err != nil {
-        rnd.JSON(w, http.StatusBadRequest, renderer.M{
-            "message": "Invalid ID format",
-        })
-        return
-    }
-
-    collection := db.Collection(collectionName)
-    filter := bson.M{"_id": objID}
-    _, err = collection.DeleteOne(context.TODO(), filter)
-    if err != nil {
-        rnd.JSON(w, http.StatusInternalServerError, renderer.M{
-            "message": "Failed to delete todo",
-            "error":   err,
-        })
-        return
-    }
-
-    rnd.JSON(w, http.StatusOK, renderer.M{
-        "message": "Todo deleted successfully",
-    })
-}
-
-func main() {
-    stopChan := make(chan os.Signal, 1)
-    signal.Notify(stopChan, os.Interrupt)
-
-    r := chi.NewRouter()
-    r.Use(middleware.Logger)
-    r.Get("/", homeHandler)
-
-    r.Mount("/todo", todoHandlers())
-
-    srv := &http.Server{
-        Addr:         port,
-        Handler:      r,
-        ReadTimeout:  60 * time.Second,
-        WriteTimeout: 60 * time.Second,
-        IdleTimeout:  60 * time.Second,
-    }
-
-    go func() {
-        log.Println("Listening on port ", port)
-        if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-            log.Fatalf("listen: %s\n", err)
-        }
-    }()
-
-    <-stopChan
-    log.Println("Shutting down server...")
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-    defer cancel()
-
-    if err := srv.Shutdown(ctx); err != nil {
-        log.Fatalf("Server Shutdown Failed:%+v", err)
-    }
-
-    // Discnnect MongoDB
-    if err := client.Disconnect(ctx); err != nil {
-        log.Fatalf("MongoDB Disconnect Failed:%+v", err)
-    }
-
-    log.Println("Server gracefully stopped!")
-}
-
-func todoHandlers() http.Handler {
-    rg := chi.NewRouter()
-    rg.Group(func(r chi.Router) {
-        r.Get("/", fetchTodos)
-        r.Post("/", createTodo)
-        r.Put("/{id}", updateTodo)
-        r.Delete("/{id}", deleteTodo)
-    })
-    return rg
-}
-
-func checkErr(err error) {
-    if err != nil {
-        log.Fatal(err)
-    }
-}
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/rs/zerolog"
+	"github.com/thedevsaddam/renderer"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+
+	"github.com/AdityaMalu/AdityaMalu.github.io/api"
+	"github.com/AdityaMalu/AdityaMalu.github.io/auth"
+	"github.com/AdityaMalu/AdityaMalu.github.io/logging"
+	"github.com/AdityaMalu/AdityaMalu.github.io/service"
+	"github.com/AdityaMalu/AdityaMalu.github.io/storage"
+	"github.com/AdityaMalu/AdityaMalu.github.io/storage/mongostore"
+	"github.com/AdityaMalu/AdityaMalu.github.io/storage/sqlitestore"
+	"github.com/AdityaMalu/AdityaMalu.github.io/ui"
+)
+
+const (
+	defaultMongoURI string = "mongodb://localhost:27017"
+	mongoDBName     string = "demo_todo"
+	collectionName  string = "todo"
+	usersCollection string = "users"
+
+	defaultSQLiteDSN string = "file:demo_todo.db?_fk=1"
+	uiTemplatesDir   string = "ui/templates"
+	port             string = ":9000"
+
+	defaultJWTTTL         = 24 * time.Hour
+	defaultRequestTimeout = 5 * time.Second
+	readyzTimeout         = 2 * time.Second
+)
+
+// newStores builds the storage.TodoStore and storage.UserStore selected by
+// the STORAGE_BACKEND env var ("mongo" or "sqlite", defaulting to "mongo"),
+// using STORAGE_DSN as the connection string. It also returns a ping func
+// for readiness checks and a cleanup func to release the underlying
+// connection on shutdown.
+func newStores() (storage.TodoStore, storage.UserStore, func(context.Context) error, func(), error) {
+	backend := os.Getenv("STORAGE_BACKEND")
+	if backend == "" {
+		backend = "mongo"
+	}
+	dsn := os.Getenv("STORAGE_DSN")
+
+	switch backend {
+	case "mongo":
+		uri := dsn
+		if uri == "" {
+			uri = defaultMongoURI
+		}
+
+		client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		if err := client.Ping(context.Background(), readpref.Primary()); err != nil {
+			return nil, nil, nil, nil, err
+		}
+
+		db := client.Database(mongoDBName)
+		users, err := mongostore.NewUserStore(context.Background(), db.Collection(usersCollection))
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+
+		ping := func(ctx context.Context) error {
+			return client.Ping(ctx, readpref.Primary())
+		}
+		closeFn := func() {
+			if err := client.Disconnect(context.Background()); err != nil {
+				log.Printf("mongo disconnect: %v", err)
+			}
+		}
+		return mongostore.New(db.Collection(collectionName)), users, ping, closeFn, nil
+
+	case "sqlite":
+		if dsn == "" {
+			dsn = defaultSQLiteDSN
+		}
+
+		store, err := sqlitestore.Open(dsn)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		closeFn := func() {
+			if err := store.Close(); err != nil {
+				log.Printf("sqlite close: %v", err)
+			}
+		}
+		return store, sqlitestore.NewUserStore(store), store.Ping, closeFn, nil
+
+	default:
+		return nil, nil, nil, nil, fmt.Errorf("unknown STORAGE_BACKEND %q", backend)
+	}
+}
+
+// jwtTTL reads JWT_TTL (a duration string like "24h") from the
+// environment, falling back to defaultJWTTTL.
+func jwtTTL() time.Duration {
+	return envDuration("JWT_TTL", defaultJWTTTL)
+}
+
+// requestTimeout reads REQUEST_TIMEOUT (a duration string like "5s") from
+// the environment, falling back to defaultRequestTimeout.
+func requestTimeout() time.Duration {
+	return envDuration("REQUEST_TIMEOUT", defaultRequestTimeout)
+}
+
+func envDuration(name string, fallback time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("invalid %s %q, using default: %v", name, v, err)
+		return fallback
+	}
+	return d
+}
+
+func homeHandler(rnd *renderer.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := rnd.Template(w, http.StatusOK, []string{"static/home.tpl"}, nil)
+		checkErr(err)
+	}
+}
+
+// healthzHandler is a liveness probe: it always succeeds once the process
+// is serving requests.
+func healthzHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
+
+// readyzHandler is a readiness probe: it pings the storage backend with a
+// short-lived context so it fails fast if the database is unreachable.
+func readyzHandler(ping func(context.Context) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readyzTimeout)
+		defer cancel()
+
+		if err := ping(ctx); err != nil {
+			http.Error(w, "not ready: "+err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ready"))
+	}
+}
+
+func main() {
+	stopChan := make(chan os.Signal, 1)
+	signal.Notify(stopChan, os.Interrupt)
+
+	store, users, ping, closeStores, err := newStores()
+	checkErr(err)
+
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		log.Fatal("JWT_SECRET must be set")
+	}
+
+	rnd := renderer.New()
+	svc := service.New(store)
+	authSvc := auth.NewService(users, []byte(secret), jwtTTL(), rnd)
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+
+	uiRouter, err := ui.NewRouter(svc, authSvc, uiTemplatesDir)
+	checkErr(err)
+
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(middleware.Recoverer)
+	r.Use(middleware.Timeout(requestTimeout()))
+	r.Use(logging.Middleware(logger))
+
+	r.Get("/", homeHandler(rnd))
+	r.Get("/healthz", healthzHandler)
+	r.Get("/readyz", readyzHandler(ping))
+
+	r.Post("/auth/register", authSvc.Register)
+	r.Post("/auth/login", authSvc.Login)
+
+	// /ui authenticates browsers via its own session cookie (see
+	// auth.Service.CookieMiddleware) rather than the bearer-JWT scheme
+	// /todo uses, since a browser following a link or submitting a form
+	// has no way to attach an Authorization header.
+	r.Mount("/ui", uiRouter)
+
+	r.Group(func(r chi.Router) {
+		r.Use(authSvc.Middleware)
+		r.Mount("/todo", api.NewRouter(svc, rnd))
+	})
+
+	srv := &http.Server{
+		Addr:         port,
+		Handler:      r,
+		ReadTimeout:  60 * time.Second,
+		WriteTimeout: 60 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+
+	go func() {
+		log.Println("Listening on port ", port)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("listen: %s\n", err)
+		}
+	}()
+
+	<-stopChan
+	log.Println("Shutting down server...")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Fatalf("Server Shutdown Failed:%+v", err)
+	}
+
+	closeStores()
+
+	log.Println("Server gracefully stopped!")
+}
+
+func checkErr(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,71 @@
+// Package service holds the domain logic shared by the JSON API (package
+// api) and the server-rendered UI (package ui), so validation rules live
+// in one place instead of being duplicated across both front ends.
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AdityaMalu/AdityaMalu.github.io/storage"
+)
+
+// ErrTitleRequired is returned by Create/Update when the title is blank.
+var ErrTitleRequired = errors.New("service: title is required")
+
+// TodoService wraps a storage.TodoStore with the validation rules both
+// front ends need to apply before persisting a todo.
+type TodoService struct {
+	store storage.TodoStore
+}
+
+// New returns a TodoService backed by store.
+func New(store storage.TodoStore) *TodoService {
+	return &TodoService{store: store}
+}
+
+// Create validates title and persists a new todo owned by ownerID.
+func (s *TodoService) Create(ctx context.Context, ownerID, title string) (storage.Todo, error) {
+	if title == "" {
+		return storage.Todo{}, ErrTitleRequired
+	}
+	return s.store.Create(ctx, ownerID, title)
+}
+
+// Update validates title and persists changes to the todo identified by id,
+// provided ownerID owns it.
+func (s *TodoService) Update(ctx context.Context, ownerID, id, title string, completed bool) error {
+	if title == "" {
+		return ErrTitleRequired
+	}
+	return s.store.Update(ctx, ownerID, id, title, completed)
+}
+
+// Delete removes the todo identified by id, provided ownerID owns it.
+func (s *TodoService) Delete(ctx context.Context, ownerID, id string) error {
+	return s.store.Delete(ctx, ownerID, id)
+}
+
+// Get returns the todo identified by id, provided ownerID owns it.
+func (s *TodoService) Get(ctx context.Context, ownerID, id string) (storage.Todo, error) {
+	return s.store.Get(ctx, ownerID, id)
+}
+
+// List returns ownerID's todos matching params.
+func (s *TodoService) List(ctx context.Context, ownerID string, params storage.ListParams) ([]storage.Todo, int64, error) {
+	return s.store.List(ctx, ownerID, params)
+}
+
+// Import bulk-persists records owned by ownerID. It does not abort on a
+// single invalid record; failures are reported in the returned
+// storage.ImportResult instead. offset is the position of records[0]
+// within the caller's overall stream, for callers importing in chunks.
+func (s *TodoService) Import(ctx context.Context, ownerID string, records []storage.ImportRecord, offset int) (storage.ImportResult, error) {
+	return s.store.Import(ctx, ownerID, records, offset)
+}
+
+// Export streams ownerID's todos to fn without loading them all into
+// memory at once.
+func (s *TodoService) Export(ctx context.Context, ownerID string, fn func(storage.Todo) error) error {
+	return s.store.Export(ctx, ownerID, fn)
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/AdityaMalu/AdityaMalu.github.io/storage"
+)
+
+// fakeStore is an in-memory storage.TodoStore, exercised here instead of a
+// real backend so the service's validation and delegation logic can be
+// tested without a database.
+type fakeStore struct {
+	todos  map[string]storage.Todo
+	nextID int
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{todos: map[string]storage.Todo{}}
+}
+
+func (f *fakeStore) Create(ctx context.Context, ownerID, title string) (storage.Todo, error) {
+	f.nextID++
+	t := storage.Todo{ID: strconv.Itoa(f.nextID), OwnerID: ownerID, Title: title}
+	f.todos[t.ID] = t
+	return t, nil
+}
+
+func (f *fakeStore) Update(ctx context.Context, ownerID, id, title string, completed bool) error {
+	t, err := f.owned(ownerID, id)
+	if err != nil {
+		return err
+	}
+	t.Title = title
+	t.Completed = completed
+	f.todos[id] = t
+	return nil
+}
+
+func (f *fakeStore) Delete(ctx context.Context, ownerID, id string) error {
+	if _, err := f.owned(ownerID, id); err != nil {
+		return err
+	}
+	delete(f.todos, id)
+	return nil
+}
+
+func (f *fakeStore) Get(ctx context.Context, ownerID, id string) (storage.Todo, error) {
+	return f.owned(ownerID, id)
+}
+
+func (f *fakeStore) List(ctx context.Context, ownerID string, params storage.ListParams) ([]storage.Todo, int64, error) {
+	var items []storage.Todo
+	for _, t := range f.todos {
+		if t.OwnerID == ownerID {
+			items = append(items, t)
+		}
+	}
+	return items, int64(len(items)), nil
+}
+
+func (f *fakeStore) Import(ctx context.Context, ownerID string, records []storage.ImportRecord, offset int) (storage.ImportResult, error) {
+	var result storage.ImportResult
+	for _, rec := range records {
+		if rec.Title == "" {
+			result.Failed++
+			continue
+		}
+		f.nextID++
+		id := strconv.Itoa(f.nextID)
+		f.todos[id] = storage.Todo{ID: id, OwnerID: ownerID, Title: rec.Title, Completed: rec.Completed}
+		result.Inserted++
+	}
+	return result, nil
+}
+
+func (f *fakeStore) Export(ctx context.Context, ownerID string, fn func(storage.Todo) error) error {
+	for _, t := range f.todos {
+		if t.OwnerID != ownerID {
+			continue
+		}
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeStore) owned(ownerID, id string) (storage.Todo, error) {
+	t, ok := f.todos[id]
+	if !ok {
+		return storage.Todo{}, storage.ErrNotFound
+	}
+	if t.OwnerID != ownerID {
+		return storage.Todo{}, storage.ErrForbidden
+	}
+	return t, nil
+}
+
+func TestTodoServiceCreateRejectsBlankTitle(t *testing.T) {
+	svc := New(newFakeStore())
+
+	if _, err := svc.Create(context.Background(), "owner-1", ""); !errors.Is(err, ErrTitleRequired) {
+		t.Fatalf("Create(blank title) error = %v, want ErrTitleRequired", err)
+	}
+}
+
+func TestTodoServiceCreateAndGet(t *testing.T) {
+	svc := New(newFakeStore())
+	ctx := context.Background()
+
+	created, err := svc.Create(ctx, "owner-1", "write tests")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, err := svc.Get(ctx, "owner-1", created.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Title != "write tests" {
+		t.Errorf("Get title = %q, want %q", got.Title, "write tests")
+	}
+}
+
+func TestTodoServiceGetByOtherOwnerIsForbidden(t *testing.T) {
+	svc := New(newFakeStore())
+	ctx := context.Background()
+
+	created, err := svc.Create(ctx, "owner-1", "private")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if _, err := svc.Get(ctx, "owner-2", created.ID); !errors.Is(err, storage.ErrForbidden) {
+		t.Fatalf("Get(other owner) error = %v, want ErrForbidden", err)
+	}
+}
+
+func TestTodoServiceUpdateRejectsBlankTitle(t *testing.T) {
+	svc := New(newFakeStore())
+	ctx := context.Background()
+
+	created, err := svc.Create(ctx, "owner-1", "original")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if err := svc.Update(ctx, "owner-1", created.ID, "", true); !errors.Is(err, ErrTitleRequired) {
+		t.Fatalf("Update(blank title) error = %v, want ErrTitleRequired", err)
+	}
+}
+
+func TestTodoServiceListScopesToOwner(t *testing.T) {
+	svc := New(newFakeStore())
+	ctx := context.Background()
+
+	if _, err := svc.Create(ctx, "owner-1", "mine"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := svc.Create(ctx, "owner-2", "theirs"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	items, total, err := svc.List(ctx, "owner-1", storage.ListParams{Limit: 10})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 1 || len(items) != 1 || items[0].Title != "mine" {
+		t.Fatalf("List(owner-1) = %+v, total = %d, want exactly one todo titled %q", items, total, "mine")
+	}
+}
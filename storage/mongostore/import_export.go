@@ -0,0 +1,92 @@
+package mongostore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/AdityaMalu/AdityaMalu.github.io/storage"
+)
+
+// importBatchSize caps how many documents a single InsertMany call writes,
+// so one oversized import doesn't build an unbounded in-memory batch.
+const importBatchSize = 500
+
+// Import implements storage.TodoStore.
+func (s *Store) Import(ctx context.Context, ownerID string, records []storage.ImportRecord, offset int) (storage.ImportResult, error) {
+	ownerObjID, err := primitive.ObjectIDFromHex(ownerID)
+	if err != nil {
+		return storage.ImportResult{}, storage.ErrForbidden
+	}
+
+	var result storage.ImportResult
+	batch := make([]interface{}, 0, importBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if _, err := s.collection.InsertMany(ctx, batch); err != nil {
+			return err
+		}
+		result.Inserted += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for i, rec := range records {
+		if rec.Title == "" {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("record %d: title is required", offset+i))
+			continue
+		}
+
+		batch = append(batch, todoModel{
+			ID:        primitive.NewObjectID(),
+			OwnerID:   ownerObjID,
+			Title:     rec.Title,
+			Completed: rec.Completed,
+			CreatedAt: time.Now(),
+		})
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// Export implements storage.TodoStore.
+func (s *Store) Export(ctx context.Context, ownerID string, fn func(storage.Todo) error) error {
+	ownerObjID, err := primitive.ObjectIDFromHex(ownerID)
+	if err != nil {
+		return storage.ErrForbidden
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	cursor, err := s.collection.Find(ctx, bson.M{"ownerId": ownerObjID}, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var tm todoModel
+		if err := cursor.Decode(&tm); err != nil {
+			return err
+		}
+		if err := fn(toDomain(tm)); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
@@ -0,0 +1,185 @@
+// Package mongostore implements storage.TodoStore and storage.UserStore on
+// top of MongoDB collections. It is the direct extraction of the Mongo
+// calls that used to live in main.go.
+package mongostore
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/AdityaMalu/AdityaMalu.github.io/storage"
+)
+
+// sortColumns maps a storage.ListParams.SortBy value to the bson field it
+// corresponds to, so callers can't sort on arbitrary document keys.
+var sortColumns = map[string]string{
+	"title":      "title",
+	"completed":  "completed",
+	"created_at": "createdAt",
+}
+
+type todoModel struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	OwnerID   primitive.ObjectID `bson:"ownerId"`
+	Title     string             `bson:"title"`
+	Completed bool               `bson:"completed"`
+	CreatedAt time.Time          `bson:"createdAt"`
+}
+
+// Store is a storage.TodoStore backed by a MongoDB collection.
+type Store struct {
+	collection *mongo.Collection
+}
+
+// New returns a Store that persists todos in the given collection.
+func New(collection *mongo.Collection) *Store {
+	return &Store{collection: collection}
+}
+
+// Create implements storage.TodoStore.
+func (s *Store) Create(ctx context.Context, ownerID, title string) (storage.Todo, error) {
+	ownerObjID, err := primitive.ObjectIDFromHex(ownerID)
+	if err != nil {
+		return storage.Todo{}, storage.ErrForbidden
+	}
+
+	tm := todoModel{
+		ID:        primitive.NewObjectID(),
+		OwnerID:   ownerObjID,
+		Title:     title,
+		Completed: false,
+		CreatedAt: time.Now(),
+	}
+	if _, err := s.collection.InsertOne(ctx, tm); err != nil {
+		return storage.Todo{}, err
+	}
+	return toDomain(tm), nil
+}
+
+// Update implements storage.TodoStore.
+func (s *Store) Update(ctx context.Context, ownerID, id, title string, completed bool) error {
+	tm, err := s.findOwned(ctx, ownerID, id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{"title": title, "completed": completed}}
+	if _, err := s.collection.UpdateOne(ctx, bson.M{"_id": tm.ID}, update); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete implements storage.TodoStore.
+func (s *Store) Delete(ctx context.Context, ownerID, id string) error {
+	tm, err := s.findOwned(ctx, ownerID, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.collection.DeleteOne(ctx, bson.M{"_id": tm.ID}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Get implements storage.TodoStore.
+func (s *Store) Get(ctx context.Context, ownerID, id string) (storage.Todo, error) {
+	tm, err := s.findOwned(ctx, ownerID, id)
+	if err != nil {
+		return storage.Todo{}, err
+	}
+	return toDomain(tm), nil
+}
+
+// findOwned looks up a todo by id and verifies ownerID owns it, returning
+// storage.ErrInvalidID if id doesn't parse as an ObjectID, storage.ErrNotFound
+// if it parses but no such document exists, and storage.ErrForbidden if it
+// exists but belongs to someone else.
+func (s *Store) findOwned(ctx context.Context, ownerID, id string) (todoModel, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return todoModel{}, storage.ErrInvalidID
+	}
+
+	var tm todoModel
+	err = s.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&tm)
+	if err == mongo.ErrNoDocuments {
+		return todoModel{}, storage.ErrNotFound
+	}
+	if err != nil {
+		return todoModel{}, err
+	}
+
+	if tm.OwnerID.Hex() != ownerID {
+		return todoModel{}, storage.ErrForbidden
+	}
+	return tm, nil
+}
+
+// List implements storage.TodoStore.
+func (s *Store) List(ctx context.Context, ownerID string, params storage.ListParams) ([]storage.Todo, int64, error) {
+	ownerObjID, err := primitive.ObjectIDFromHex(ownerID)
+	if err != nil {
+		return nil, 0, storage.ErrForbidden
+	}
+
+	filter := bson.M{"ownerId": ownerObjID}
+	if params.Completed != nil {
+		filter["completed"] = *params.Completed
+	}
+
+	total, err := s.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sortColumn := "createdAt"
+	if col, ok := sortColumns[params.SortBy]; ok {
+		sortColumn = col
+	}
+	sortOrder := 1
+	if params.SortDesc {
+		sortOrder = -1
+	}
+
+	opts := options.Find().
+		SetLimit(params.Limit).
+		SetSkip(params.Offset).
+		SetSort(bson.D{{Key: sortColumn, Value: sortOrder}})
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	todos := []storage.Todo{}
+	for cursor.Next(ctx) {
+		var tm todoModel
+		if err := cursor.Decode(&tm); err != nil {
+			return nil, 0, err
+		}
+		todos = append(todos, toDomain(tm))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return todos, total, nil
+}
+
+func toDomain(tm todoModel) storage.Todo {
+	return storage.Todo{
+		ID:        tm.ID.Hex(),
+		OwnerID:   tm.OwnerID.Hex(),
+		Title:     tm.Title,
+		Completed: tm.Completed,
+		CreatedAt: tm.CreatedAt,
+	}
+}
@@ -0,0 +1,95 @@
+package mongostore
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/AdityaMalu/AdityaMalu.github.io/storage"
+)
+
+type userModel struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	Username     string             `bson:"username"`
+	PasswordHash string             `bson:"passwordHash"`
+	CreatedAt    time.Time          `bson:"createdAt"`
+}
+
+// UserStore is a storage.UserStore backed by a MongoDB collection.
+type UserStore struct {
+	collection *mongo.Collection
+}
+
+// NewUserStore returns a UserStore that persists accounts in the given
+// collection. It ensures a unique index on username.
+func NewUserStore(ctx context.Context, collection *mongo.Collection) (*UserStore, error) {
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.M{"username": 1},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &UserStore{collection: collection}, nil
+}
+
+// CreateUser implements storage.UserStore.
+func (s *UserStore) CreateUser(ctx context.Context, username, passwordHash string) (storage.User, error) {
+	um := userModel{
+		ID:           primitive.NewObjectID(),
+		Username:     username,
+		PasswordHash: passwordHash,
+		CreatedAt:    time.Now(),
+	}
+	if _, err := s.collection.InsertOne(ctx, um); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return storage.User{}, storage.ErrDuplicateUser
+		}
+		return storage.User{}, err
+	}
+	return toUserDomain(um), nil
+}
+
+// GetUserByUsername implements storage.UserStore.
+func (s *UserStore) GetUserByUsername(ctx context.Context, username string) (storage.User, error) {
+	var um userModel
+	err := s.collection.FindOne(ctx, bson.M{"username": username}).Decode(&um)
+	if err == mongo.ErrNoDocuments {
+		return storage.User{}, storage.ErrNotFound
+	}
+	if err != nil {
+		return storage.User{}, err
+	}
+	return toUserDomain(um), nil
+}
+
+// GetUserByID implements storage.UserStore.
+func (s *UserStore) GetUserByID(ctx context.Context, id string) (storage.User, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return storage.User{}, storage.ErrNotFound
+	}
+
+	var um userModel
+	err = s.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&um)
+	if err == mongo.ErrNoDocuments {
+		return storage.User{}, storage.ErrNotFound
+	}
+	if err != nil {
+		return storage.User{}, err
+	}
+	return toUserDomain(um), nil
+}
+
+func toUserDomain(um userModel) storage.User {
+	return storage.User{
+		ID:           um.ID.Hex(),
+		Username:     um.Username,
+		PasswordHash: um.PasswordHash,
+		CreatedAt:    um.CreatedAt,
+	}
+}
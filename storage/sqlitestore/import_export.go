@@ -0,0 +1,126 @@
+package sqlitestore
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/AdityaMalu/AdityaMalu.github.io/storage"
+)
+
+// importBatchSize caps how many rows a single transaction writes, so one
+// oversized import doesn't hold an unbounded number of pending rows open.
+const importBatchSize = 500
+
+// exportPageSize is how many rows Export fetches per page while streaming,
+// so a large export doesn't load the whole table into memory at once.
+const exportPageSize = 500
+
+// Import implements storage.TodoStore.
+func (s *Store) Import(ctx context.Context, ownerID string, records []storage.ImportRecord, offset int) (storage.ImportResult, error) {
+	ownerOID, err := strconv.ParseInt(ownerID, 10, 64)
+	if err != nil {
+		return storage.ImportResult{}, storage.ErrForbidden
+	}
+
+	var result storage.ImportResult
+	batch := make([]storage.ImportRecord, 0, importBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		stmt, err := tx.PrepareContext(ctx,
+			`INSERT INTO todos (owner_id, title, completed, created_at) VALUES (?, ?, ?, CURRENT_TIMESTAMP)`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, rec := range batch {
+			if _, err := stmt.ExecContext(ctx, ownerOID, rec.Title, rec.Completed); err != nil {
+				return err
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+
+		result.Inserted += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for i, rec := range records {
+		if rec.Title == "" {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("record %d: title is required", offset+i))
+			continue
+		}
+
+		batch = append(batch, rec)
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// Export implements storage.TodoStore. It pages through the table rather
+// than using a single query, so it doesn't hold a result set of unbounded
+// size open for the duration of the stream.
+func (s *Store) Export(ctx context.Context, ownerID string, fn func(storage.Todo) error) error {
+	ownerOID, err := strconv.ParseInt(ownerID, 10, 64)
+	if err != nil {
+		return storage.ErrForbidden
+	}
+
+	for offset := 0; ; offset += exportPageSize {
+		rows, err := s.db.QueryContext(ctx,
+			`SELECT id, owner_id, title, completed, created_at FROM todos
+			 WHERE owner_id = ? ORDER BY created_at ASC LIMIT ? OFFSET ?`,
+			ownerOID, exportPageSize, offset)
+		if err != nil {
+			return err
+		}
+
+		var page []storage.Todo
+		for rows.Next() {
+			var t storage.Todo
+			var rowOwnerID int64
+			if err := rows.Scan(&t.ID, &rowOwnerID, &t.Title, &t.Completed, &t.CreatedAt); err != nil {
+				rows.Close()
+				return err
+			}
+			t.OwnerID = strconv.FormatInt(rowOwnerID, 10)
+			page = append(page, t)
+		}
+		rowsErr := rows.Err()
+		rows.Close()
+		if rowsErr != nil {
+			return rowsErr
+		}
+		if len(page) == 0 {
+			return nil
+		}
+
+		for _, t := range page {
+			if err := fn(t); err != nil {
+				return err
+			}
+		}
+	}
+}
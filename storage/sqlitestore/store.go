@@ -0,0 +1,209 @@
+// Package sqlitestore implements storage.TodoStore and storage.UserStore on
+// top of SQLite via database/sql and the mattn/go-sqlite3 driver.
+//
+// The SQL is hand-written rather than produced by a generated client, so
+// the package is self-contained and doesn't depend on a code-generation
+// step.
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/AdityaMalu/AdityaMalu.github.io/storage"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS users (
+	id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	username      TEXT NOT NULL UNIQUE,
+	password_hash TEXT NOT NULL,
+	created_at    TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS todos (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id   INTEGER NOT NULL,
+	title      TEXT NOT NULL,
+	completed  BOOLEAN NOT NULL DEFAULT 0,
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS todos_owner_id_idx ON todos (owner_id);
+`
+
+// sortColumns maps a storage.ListParams.SortBy value to the column it
+// corresponds to, so callers can't sort on arbitrary columns.
+var sortColumns = map[string]string{
+	"title":      "title",
+	"completed":  "completed",
+	"created_at": "created_at",
+}
+
+// Store is a storage.TodoStore backed by a SQLite database.
+type Store struct {
+	db *sql.DB
+}
+
+// Open creates (or migrates) the SQLite database at dsn and returns a Store
+// backed by it. Callers are responsible for calling Close when done.
+func Open(dsn string) (*Store, error) {
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close releases the underlying database connection.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Ping verifies the database is reachable, for use by readiness checks.
+func (s *Store) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+// Create implements storage.TodoStore.
+func (s *Store) Create(ctx context.Context, ownerID, title string) (storage.Todo, error) {
+	ownerOID, err := strconv.ParseInt(ownerID, 10, 64)
+	if err != nil {
+		return storage.Todo{}, storage.ErrForbidden
+	}
+
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO todos (owner_id, title, completed, created_at) VALUES (?, ?, 0, CURRENT_TIMESTAMP)`,
+		ownerOID, title)
+	if err != nil {
+		return storage.Todo{}, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return storage.Todo{}, err
+	}
+	return s.Get(ctx, ownerID, strconv.FormatInt(id, 10))
+}
+
+// Update implements storage.TodoStore.
+func (s *Store) Update(ctx context.Context, ownerID, id, title string, completed bool) error {
+	if _, err := s.findOwned(ctx, ownerID, id); err != nil {
+		return err
+	}
+
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE todos SET title = ?, completed = ? WHERE id = ?`,
+		title, completed, id)
+	return err
+}
+
+// Delete implements storage.TodoStore.
+func (s *Store) Delete(ctx context.Context, ownerID, id string) error {
+	if _, err := s.findOwned(ctx, ownerID, id); err != nil {
+		return err
+	}
+
+	_, err := s.db.ExecContext(ctx, `DELETE FROM todos WHERE id = ?`, id)
+	return err
+}
+
+// Get implements storage.TodoStore.
+func (s *Store) Get(ctx context.Context, ownerID, id string) (storage.Todo, error) {
+	return s.findOwned(ctx, ownerID, id)
+}
+
+// findOwned looks up a todo by id and verifies ownerID owns it, returning
+// storage.ErrInvalidID if id doesn't parse as an integer, storage.ErrNotFound
+// if it parses but no such row exists, and storage.ErrForbidden if it exists
+// but belongs to someone else.
+func (s *Store) findOwned(ctx context.Context, ownerID, id string) (storage.Todo, error) {
+	oid, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return storage.Todo{}, storage.ErrInvalidID
+	}
+
+	var t storage.Todo
+	var rowOwnerID int64
+	err = s.db.QueryRowContext(ctx,
+		`SELECT id, owner_id, title, completed, created_at FROM todos WHERE id = ?`, oid).
+		Scan(&t.ID, &rowOwnerID, &t.Title, &t.Completed, &t.CreatedAt)
+	if err == sql.ErrNoRows {
+		return storage.Todo{}, storage.ErrNotFound
+	}
+	if err != nil {
+		return storage.Todo{}, err
+	}
+
+	t.OwnerID = strconv.FormatInt(rowOwnerID, 10)
+	if t.OwnerID != ownerID {
+		return storage.Todo{}, storage.ErrForbidden
+	}
+	return t, nil
+}
+
+// List implements storage.TodoStore.
+func (s *Store) List(ctx context.Context, ownerID string, params storage.ListParams) ([]storage.Todo, int64, error) {
+	ownerOID, err := strconv.ParseInt(ownerID, 10, 64)
+	if err != nil {
+		return nil, 0, storage.ErrForbidden
+	}
+
+	where := "owner_id = ?"
+	args := []any{ownerOID}
+	if params.Completed != nil {
+		where += " AND completed = ?"
+		args = append(args, *params.Completed)
+	}
+
+	var total int64
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM todos WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	column := sortColumns["created_at"]
+	if c, ok := sortColumns[params.SortBy]; ok {
+		column = c
+	}
+	order := "ASC"
+	if params.SortDesc {
+		order = "DESC"
+	}
+
+	query := `SELECT id, owner_id, title, completed, created_at FROM todos WHERE ` + where +
+		` ORDER BY ` + column + ` ` + order + ` LIMIT ? OFFSET ?`
+	rows, err := s.db.QueryContext(ctx, query, append(args, params.Limit, params.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var todos []storage.Todo
+	for rows.Next() {
+		var t storage.Todo
+		var rowOwnerID int64
+		if err := rows.Scan(&t.ID, &rowOwnerID, &t.Title, &t.Completed, &t.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		t.OwnerID = strconv.FormatInt(rowOwnerID, 10)
+		todos = append(todos, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return todos, total, nil
+}
+
+// isUniqueConstraintErr reports whether err is a SQLite UNIQUE constraint
+// violation.
+func isUniqueConstraintErr(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
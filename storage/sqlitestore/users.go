@@ -0,0 +1,77 @@
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/AdityaMalu/AdityaMalu.github.io/storage"
+)
+
+// UserStore is a storage.UserStore backed by a SQLite database. It shares
+// the same *sql.DB as Store, since both live in the same SQLite file.
+type UserStore struct {
+	db *sql.DB
+}
+
+// NewUserStore returns a UserStore backed by the same database as s.
+func NewUserStore(s *Store) *UserStore {
+	return &UserStore{db: s.db}
+}
+
+// CreateUser implements storage.UserStore.
+func (s *UserStore) CreateUser(ctx context.Context, username, passwordHash string) (storage.User, error) {
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO users (username, password_hash, created_at) VALUES (?, ?, CURRENT_TIMESTAMP)`,
+		username, passwordHash)
+	if isUniqueConstraintErr(err) {
+		return storage.User{}, storage.ErrDuplicateUser
+	}
+	if err != nil {
+		return storage.User{}, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return storage.User{}, err
+	}
+	return s.GetUserByID(ctx, strconv.FormatInt(id, 10))
+}
+
+// GetUserByUsername implements storage.UserStore.
+func (s *UserStore) GetUserByUsername(ctx context.Context, username string) (storage.User, error) {
+	var u storage.User
+	var id int64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, username, password_hash, created_at FROM users WHERE username = ?`, username).
+		Scan(&id, &u.Username, &u.PasswordHash, &u.CreatedAt)
+	if err == sql.ErrNoRows {
+		return storage.User{}, storage.ErrNotFound
+	}
+	if err != nil {
+		return storage.User{}, err
+	}
+	u.ID = strconv.FormatInt(id, 10)
+	return u, nil
+}
+
+// GetUserByID implements storage.UserStore.
+func (s *UserStore) GetUserByID(ctx context.Context, id string) (storage.User, error) {
+	oid, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return storage.User{}, storage.ErrNotFound
+	}
+
+	var u storage.User
+	err = s.db.QueryRowContext(ctx,
+		`SELECT id, username, password_hash, created_at FROM users WHERE id = ?`, oid).
+		Scan(&oid, &u.Username, &u.PasswordHash, &u.CreatedAt)
+	if err == sql.ErrNoRows {
+		return storage.User{}, storage.ErrNotFound
+	}
+	if err != nil {
+		return storage.User{}, err
+	}
+	u.ID = id
+	return u, nil
+}
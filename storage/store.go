@@ -0,0 +1,109 @@
+// Package storage defines the persistence contract shared by the todo
+// backends (MongoDB, SQLite) and the domain types handlers exchange with
+// them, so the HTTP layer never depends on a concrete database driver.
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by TodoStore/UserStore implementations when the
+// requested record does not exist.
+var ErrNotFound = errors.New("storage: not found")
+
+// ErrInvalidID is returned by TodoStore implementations when an id doesn't
+// even parse as one of the backend's native id types, as distinct from
+// ErrNotFound (a well-formed id with no matching record): the former is a
+// caller error worth a 400, the latter just means the record isn't there.
+var ErrInvalidID = errors.New("storage: invalid id")
+
+// ErrForbidden is returned by TodoStore implementations when the requested
+// todo exists but is owned by a different user.
+var ErrForbidden = errors.New("storage: owned by another user")
+
+// ErrDuplicateUser is returned by UserStore.CreateUser when the username is
+// already taken.
+var ErrDuplicateUser = errors.New("storage: username already exists")
+
+// Todo is the storage-layer representation of a todo item, independent of
+// any particular backend's native document/row format.
+type Todo struct {
+	ID        string
+	OwnerID   string
+	Title     string
+	Completed bool
+	CreatedAt time.Time
+}
+
+// ListParams carries the paging, filtering and sorting options accepted by
+// TodoStore.List. SortBy is one of "title", "completed" or "created_at";
+// an unrecognised value falls back to the implementation's default sort.
+type ListParams struct {
+	Limit     int64
+	Offset    int64
+	SortBy    string
+	SortDesc  bool
+	Completed *bool
+}
+
+// ImportRecord is a single record accepted by TodoStore.Import.
+type ImportRecord struct {
+	Title     string `json:"title"`
+	Completed bool   `json:"completed"`
+}
+
+// ImportResult summarizes the outcome of a TodoStore.Import call. A
+// per-record failure (e.g. a blank title) is reported here rather than
+// aborting the whole batch.
+type ImportResult struct {
+	Inserted int
+	Failed   int
+	Errors   []string
+}
+
+// TodoStore is implemented by every backend that can persist todos.
+// Handlers depend on this interface rather than on a concrete backend so
+// the backend can be swapped via configuration and faked in tests.
+//
+// Every method is scoped to ownerID: List/Export only return that owner's
+// todos, and Update/Delete/Get return ErrForbidden if the todo exists but
+// belongs to a different owner.
+type TodoStore interface {
+	Create(ctx context.Context, ownerID, title string) (Todo, error)
+	Update(ctx context.Context, ownerID, id, title string, completed bool) error
+	Delete(ctx context.Context, ownerID, id string) error
+	Get(ctx context.Context, ownerID, id string) (Todo, error)
+	List(ctx context.Context, ownerID string, params ListParams) (items []Todo, total int64, err error)
+
+	// Import persists records in bulk, batching writes internally. It
+	// never aborts on a single invalid record; failures are reported in
+	// the returned ImportResult instead. offset is the position of
+	// records[0] within the caller's overall stream, so that a caller
+	// importing in chunks can pass a running total and get back
+	// ImportResult.Errors indexed against the whole stream rather than
+	// just this call's records.
+	Import(ctx context.Context, ownerID string, records []ImportRecord, offset int) (ImportResult, error)
+
+	// Export streams every one of ownerID's todos to fn, in creation
+	// order, without loading them all into memory at once. It stops and
+	// returns fn's error as soon as fn returns one.
+	Export(ctx context.Context, ownerID string, fn func(Todo) error) error
+}
+
+// User is the storage-layer representation of a registered account.
+type User struct {
+	ID           string
+	Username     string
+	PasswordHash string
+	CreatedAt    time.Time
+}
+
+// UserStore is implemented by every backend that can persist user
+// accounts for the auth subsystem.
+type UserStore interface {
+	CreateUser(ctx context.Context, username, passwordHash string) (User, error)
+	GetUserByUsername(ctx context.Context, username string) (User, error)
+	GetUserByID(ctx context.Context, id string) (User, error)
+}
@@ -0,0 +1,208 @@
+// Package ui serves the server-rendered HTML views for browsing and
+// editing todos, mounted at /ui by main. Like package api, it depends on
+// a service.TodoService rather than a storage.TodoStore directly.
+package ui
+
+import (
+	"html/template"
+	"net/http"
+	"path/filepath"
+
+	"github.com/go-chi/chi"
+
+	"github.com/AdityaMalu/AdityaMalu.github.io/auth"
+	"github.com/AdityaMalu/AdityaMalu.github.io/service"
+	"github.com/AdityaMalu/AdityaMalu.github.io/storage"
+)
+
+// pages lists the content templates rendered inside layout.html. Each is
+// parsed together with the layout into its own *template.Template so
+// their "content" blocks don't collide with one another.
+var pages = []string{"todos-list.html", "todo-info.html", "todo-form.html", "login.html", "register.html"}
+
+type handler struct {
+	svc       *service.TodoService
+	templates map[string]*template.Template
+}
+
+// NewRouter returns the /ui sub-router, parsing its templates from
+// templatesDir (layout.html plus one file per page listed in pages).
+// /ui/todos and friends require a session cookie, issued by authSvc's
+// login/register forms, which are themselves open to anyone.
+func NewRouter(svc *service.TodoService, authSvc *auth.Service, templatesDir string) (http.Handler, error) {
+	h := &handler{svc: svc, templates: map[string]*template.Template{}}
+
+	layout := filepath.Join(templatesDir, "layout.html")
+	for _, page := range pages {
+		tmpl, err := template.ParseFiles(layout, filepath.Join(templatesDir, page))
+		if err != nil {
+			return nil, err
+		}
+		h.templates[page] = tmpl
+	}
+
+	r := chi.NewRouter()
+	r.Get("/login", h.loginForm)
+	r.Post("/login", authSvc.WebLogin)
+	r.Get("/register", h.registerForm)
+	r.Post("/register", authSvc.WebRegister)
+	r.Post("/logout", authSvc.WebLogout)
+
+	r.Group(func(r chi.Router) {
+		r.Use(authSvc.CookieMiddleware)
+		r.Get("/todos", h.list)
+		r.Get("/todos/new", h.newForm)
+		r.Post("/todos", h.create)
+		r.Get("/todos/{id}", h.detail)
+		r.Get("/todos/{id}/edit", h.editForm)
+		r.Post("/todos/{id}", h.update)
+		r.Post("/todos/{id}/delete", h.delete)
+	})
+	return r, nil
+}
+
+func (h *handler) loginForm(w http.ResponseWriter, r *http.Request) {
+	h.render(w, "login.html", map[string]interface{}{
+		"Error": r.URL.Query().Get("error") != "",
+	})
+}
+
+func (h *handler) registerForm(w http.ResponseWriter, r *http.Request) {
+	h.render(w, "register.html", map[string]interface{}{
+		"Error": r.URL.Query().Get("error") != "",
+	})
+}
+
+func (h *handler) render(w http.ResponseWriter, page string, data interface{}) {
+	tmpl, ok := h.templates[page]
+	if !ok {
+		http.Error(w, "template not found: "+page, http.StatusInternalServerError)
+		return
+	}
+	if err := tmpl.ExecuteTemplate(w, "layout.html", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *handler) list(w http.ResponseWriter, r *http.Request) {
+	ownerID, _ := auth.UserID(r.Context())
+
+	items, _, err := h.svc.List(r.Context(), ownerID, storage.ListParams{
+		Limit:    maxListSize,
+		SortBy:   "created_at",
+		SortDesc: true,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.render(w, "todos-list.html", map[string]interface{}{"Todos": items})
+}
+
+func (h *handler) detail(w http.ResponseWriter, r *http.Request) {
+	ownerID, _ := auth.UserID(r.Context())
+	id := chi.URLParam(r, "id")
+
+	t, err := h.svc.Get(r.Context(), ownerID, id)
+	switch err {
+	case nil:
+		h.render(w, "todo-info.html", map[string]interface{}{"Todo": t})
+	case storage.ErrNotFound, storage.ErrInvalidID:
+		http.NotFound(w, r)
+	case storage.ErrForbidden:
+		http.Error(w, "forbidden", http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *handler) newForm(w http.ResponseWriter, r *http.Request) {
+	h.render(w, "todo-form.html", map[string]interface{}{
+		"Todo":   storage.Todo{},
+		"Action": "/ui/todos",
+	})
+}
+
+func (h *handler) editForm(w http.ResponseWriter, r *http.Request) {
+	ownerID, _ := auth.UserID(r.Context())
+	id := chi.URLParam(r, "id")
+
+	t, err := h.svc.Get(r.Context(), ownerID, id)
+	switch err {
+	case nil:
+		h.render(w, "todo-form.html", map[string]interface{}{
+			"Todo":   t,
+			"Action": "/ui/todos/" + t.ID,
+		})
+	case storage.ErrNotFound, storage.ErrInvalidID:
+		http.NotFound(w, r)
+	case storage.ErrForbidden:
+		http.Error(w, "forbidden", http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *handler) create(w http.ResponseWriter, r *http.Request) {
+	ownerID, _ := auth.UserID(r.Context())
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.svc.Create(r.Context(), ownerID, r.FormValue("title"))
+	switch err {
+	case nil:
+		http.Redirect(w, r, "/ui/todos", http.StatusSeeOther)
+	case service.ErrTitleRequired:
+		http.Error(w, "title is required", http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *handler) update(w http.ResponseWriter, r *http.Request) {
+	ownerID, _ := auth.UserID(r.Context())
+	id := chi.URLParam(r, "id")
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	completed := r.FormValue("completed") == "on"
+	err := h.svc.Update(r.Context(), ownerID, id, r.FormValue("title"), completed)
+	switch err {
+	case nil:
+		http.Redirect(w, r, "/ui/todos/"+id, http.StatusSeeOther)
+	case service.ErrTitleRequired:
+		http.Error(w, "title is required", http.StatusBadRequest)
+	case storage.ErrNotFound, storage.ErrInvalidID:
+		http.NotFound(w, r)
+	case storage.ErrForbidden:
+		http.Error(w, "forbidden", http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *handler) delete(w http.ResponseWriter, r *http.Request) {
+	ownerID, _ := auth.UserID(r.Context())
+	id := chi.URLParam(r, "id")
+
+	err := h.svc.Delete(r.Context(), ownerID, id)
+	switch err {
+	case nil, storage.ErrNotFound, storage.ErrInvalidID:
+		http.Redirect(w, r, "/ui/todos", http.StatusSeeOther)
+	case storage.ErrForbidden:
+		http.Error(w, "forbidden", http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// maxListSize caps how many todos the list page fetches at once; it has
+// no pagination controls yet.
+const maxListSize = 100